@@ -0,0 +1,213 @@
+// Package ast defines the syntax tree node types produced by the parser.
+package ast
+
+import "goto/token"
+
+// Statement is implemented by every node that can appear directly in a
+// Program or BlockStatement.
+type Statement interface {
+	statementNode()
+}
+
+// Expression is implemented by every node that can appear in expression
+// position.
+type Expression interface {
+	expressionNode()
+}
+
+// Comment is a single `//` or `/* */` comment token.
+type Comment struct {
+	Token token.Token
+	Text  string
+}
+
+// CommentGroup is a run of comments attached to a single node.
+type CommentGroup struct {
+	List []*Comment
+}
+
+// Comments is embedded in every statement type so the parser can attach
+// comments without each statement redeclaring the same two fields.
+// LeadComment holds comments that preceded the statement; LineComment
+// holds a trailing `//` or `/* */` comment that starts on the statement's
+// own last line.
+type Comments struct {
+	LeadComment *CommentGroup
+	LineComment *CommentGroup
+}
+
+// Program is the root node of a fully parsed source file.
+type Program struct {
+	Statements []Statement
+}
+
+// Identifier is a bare name, e.g. in `var x = ...` or as a call argument.
+type Identifier struct {
+	Token token.Token
+	Value string
+}
+
+func (*Identifier) expressionNode() {}
+
+// IdentifierList is a parenthesized, comma-separated list of identifiers,
+// e.g. function parameters.
+type IdentifierList struct {
+	Token       token.Token
+	Identifiers []*Identifier
+}
+
+// IntegerLiteral is an integer literal, e.g. `5`.
+type IntegerLiteral struct {
+	Token token.Token
+	Value int64
+}
+
+func (*IntegerLiteral) expressionNode() {}
+
+// Boolean is `true` or `false`.
+type Boolean struct {
+	Token token.Token
+	Value bool
+}
+
+func (*Boolean) expressionNode() {}
+
+// String is a string literal, e.g. `"hi"`.
+type String struct {
+	Token token.Token
+	Value string
+}
+
+func (*String) expressionNode() {}
+
+// PrefixExpression is a unary operator applied to its operand, e.g. `-x`
+// or `!x`.
+type PrefixExpression struct {
+	Token    token.Token
+	Operator string
+	Right    Expression
+}
+
+func (*PrefixExpression) expressionNode() {}
+
+// InfixExpression is a binary operator applied to two operands, e.g.
+// `x + y`.
+type InfixExpression struct {
+	Token    token.Token
+	Operator string
+	Left     Expression
+	Right    Expression
+}
+
+func (*InfixExpression) expressionNode() {}
+
+// ExpressionList is a comma-separated list of expressions, e.g. call
+// arguments or array elements.
+type ExpressionList struct {
+	Token       token.Token
+	Expressions []*Expression
+}
+
+// CallExpression is a function call, e.g. `f(1, 2)`. Function may be any
+// Expression, not just an Identifier, since function literals and the
+// results of other calls/indexing are callable too.
+type CallExpression struct {
+	Token        token.Token
+	Function     Expression
+	ArgumentList *ExpressionList
+}
+
+func (*CallExpression) expressionNode() {}
+
+// ArrayLiteral is an array literal, e.g. `[1, 2, 3]`.
+type ArrayLiteral struct {
+	Token    token.Token
+	Elements *ExpressionList
+}
+
+func (*ArrayLiteral) expressionNode() {}
+
+// IndexExpression is an index operation, e.g. `arr[i]`.
+type IndexExpression struct {
+	Token token.Token
+	Left  Expression
+	Index Expression
+}
+
+func (*IndexExpression) expressionNode() {}
+
+// HashPair is a single key/value pair inside a HashLiteral.
+type HashPair struct {
+	Key   Expression
+	Value Expression
+}
+
+// HashLiteral is a hash literal, e.g. `{"a": 1, "b": 2}`.
+type HashLiteral struct {
+	Token token.Token
+	Pairs []HashPair
+}
+
+func (*HashLiteral) expressionNode() {}
+
+// FunctionLiteral is a function literal in expression position, e.g.
+// `func(x, y) { return x + y; }`.
+type FunctionLiteral struct {
+	Token      token.Token
+	Parameters *IdentifierList
+	Body       *BlockStatement
+}
+
+func (*FunctionLiteral) expressionNode() {}
+
+// VarStatement is `var name = value;`.
+type VarStatement struct {
+	Token token.Token
+	Name  *Identifier
+	Value Expression
+	Comments
+}
+
+func (*VarStatement) statementNode() {}
+
+// ReturnStatement is `return value;`.
+type ReturnStatement struct {
+	Token       token.Token
+	ReturnValue Expression
+	Comments
+}
+
+func (*ReturnStatement) statementNode() {}
+
+// IfStatement is `if cond { ... } else ...`. FollowIf holds a chained
+// `else if`, so an if/else-if/else chain is a singly linked list of
+// IfStatements rather than nested Alternative blocks.
+type IfStatement struct {
+	Token       token.Token
+	Condition   Expression
+	Consequence *BlockStatement
+	Alternative *BlockStatement
+	FollowIf    *IfStatement
+	Comments
+}
+
+func (*IfStatement) statementNode() {}
+
+// BlockStatement is a `{ ... }` sequence of statements.
+type BlockStatement struct {
+	Token      token.Token
+	Statements []Statement
+	Comments
+}
+
+func (*BlockStatement) statementNode() {}
+
+// ExpressionStatement is a bare expression used as a statement, e.g. a
+// call made for its side effects.
+type ExpressionStatement struct {
+	Token      token.Token
+	Expression Expression
+	Comments
+}
+
+func (*ExpressionStatement) statementNode() {}
@@ -0,0 +1,212 @@
+// Package lexer turns source text into a stream of token.Tokens for the
+// parser to consume.
+package lexer
+
+import "goto/token"
+
+// Lexer scans a fixed input string one token at a time via NextToken. It
+// holds no other state, so it's cheap to construct per parse.
+type Lexer struct {
+	input   string
+	pos     int // position of ch
+	readPos int // position after ch
+	ch      byte
+
+	line   int
+	column int
+}
+
+// New creates a Lexer over input, positioned before the first character.
+func New(input string) *Lexer {
+	l := &Lexer{input: input, line: 1}
+	l.readChar()
+	return l
+}
+
+func (l *Lexer) readChar() {
+	if l.readPos >= len(l.input) {
+		l.ch = 0
+	} else {
+		l.ch = l.input[l.readPos]
+	}
+	l.pos = l.readPos
+	l.readPos++
+
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	} else {
+		l.column++
+	}
+}
+
+func (l *Lexer) peekChar() byte {
+	if l.readPos >= len(l.input) {
+		return 0
+	}
+	return l.input[l.readPos]
+}
+
+func (l *Lexer) skipWhitespace() {
+	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+		l.readChar()
+	}
+}
+
+// NextToken scans and returns the next token, advancing past it. It
+// returns an EOF token forever once the input is exhausted.
+func (l *Lexer) NextToken() token.Token {
+	l.skipWhitespace()
+
+	line, column := l.line, l.column
+
+	var tok token.Token
+	switch l.ch {
+	case '=':
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = token.Token{Type: token.EQ, Literal: "=="}
+		} else {
+			tok = token.Token{Type: token.ASSIGN, Literal: "="}
+		}
+	case '!':
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = token.Token{Type: token.NOT_EQ, Literal: "!="}
+		} else {
+			tok = token.Token{Type: token.NOT, Literal: "!"}
+		}
+	case '<':
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = token.Token{Type: token.LT_EQ, Literal: "<="}
+		} else {
+			tok = token.Token{Type: token.LT, Literal: "<"}
+		}
+	case '>':
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = token.Token{Type: token.GT_EQ, Literal: ">="}
+		} else {
+			tok = token.Token{Type: token.GT, Literal: ">"}
+		}
+	case '+':
+		tok = token.Token{Type: token.PLUS, Literal: "+"}
+	case '-':
+		tok = token.Token{Type: token.MINUS, Literal: "-"}
+	case '*':
+		tok = token.Token{Type: token.MULTIPLY, Literal: "*"}
+	case '/':
+		switch l.peekChar() {
+		case '/':
+			return l.readLineComment(line, column)
+		case '*':
+			return l.readBlockComment(line, column)
+		default:
+			tok = token.Token{Type: token.DIVIDE, Literal: "/"}
+		}
+	case ',':
+		tok = token.Token{Type: token.COMMA, Literal: ","}
+	case ';':
+		tok = token.Token{Type: token.SEMI, Literal: ";"}
+	case ':':
+		tok = token.Token{Type: token.COLON, Literal: ":"}
+	case '(':
+		tok = token.Token{Type: token.LPAREN, Literal: "("}
+	case ')':
+		tok = token.Token{Type: token.RPAREN, Literal: ")"}
+	case '{':
+		tok = token.Token{Type: token.LBRACE, Literal: "{"}
+	case '}':
+		tok = token.Token{Type: token.RBRACE, Literal: "}"}
+	case '[':
+		tok = token.Token{Type: token.LBRACKET, Literal: "["}
+	case ']':
+		tok = token.Token{Type: token.RBRACKET, Literal: "]"}
+	case '"':
+		tok = token.Token{Type: token.STRING, Literal: l.readString()}
+	case 0:
+		tok = token.Token{Type: token.EOF, Literal: ""}
+	default:
+		switch {
+		case isLetter(l.ch):
+			literal := l.readIdentifier()
+			return token.Token{Type: token.LookupIdent(literal), Literal: literal, Line: line, Column: column}
+		case isDigit(l.ch):
+			return token.Token{Type: token.INT, Literal: l.readNumber(), Line: line, Column: column}
+		default:
+			tok = token.Token{Type: token.ILLEGAL, Literal: string(l.ch)}
+		}
+	}
+
+	tok.Line, tok.Column = line, column
+	l.readChar()
+	return tok
+}
+
+// readLineComment scans a `// ...` comment through end of line (or EOF),
+// not including the terminating newline.
+func (l *Lexer) readLineComment(line, column int) token.Token {
+	start := l.pos
+	for l.ch != '\n' && l.ch != 0 {
+		l.readChar()
+	}
+	return token.Token{Type: token.COMMENT, Literal: l.input[start:l.pos], Line: line, Column: column}
+}
+
+// readBlockComment scans a `/* ... */` comment, including unterminated
+// ones that run to EOF.
+func (l *Lexer) readBlockComment(line, column int) token.Token {
+	start := l.pos
+	l.readChar() // consume '/'
+	l.readChar() // consume '*'
+	for {
+		if l.ch == 0 {
+			break
+		}
+		if l.ch == '*' && l.peekChar() == '/' {
+			l.readChar()
+			l.readChar()
+			break
+		}
+		l.readChar()
+	}
+	return token.Token{Type: token.COMMENT, Literal: l.input[start:l.pos], Line: line, Column: column}
+}
+
+func (l *Lexer) readIdentifier() string {
+	start := l.pos
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+	return l.input[start:l.pos]
+}
+
+func (l *Lexer) readNumber() string {
+	start := l.pos
+	for isDigit(l.ch) {
+		l.readChar()
+	}
+	return l.input[start:l.pos]
+}
+
+// readString scans the contents of a double-quoted string literal,
+// excluding the surrounding quotes. It does not process escape sequences.
+func (l *Lexer) readString() string {
+	start := l.pos + 1
+	for {
+		l.readChar()
+		if l.ch == '"' || l.ch == 0 {
+			break
+		}
+	}
+	return l.input[start:l.pos]
+}
+
+func isLetter(ch byte) bool {
+	return ch == '_' || 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z'
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"goto/ast"
+	"goto/token"
+)
+
+// fetchToken reads the next non-comment token from the lexer. When Mode has
+// ParseComments set, any token.COMMENT tokens encountered along the way are
+// buffered in p.pendingComments instead of being handed to the rest of the
+// parser; otherwise they're dropped. This is the single place comment
+// tokens enter or leave the parser, so nextToken/setToken don't need to
+// know about them at all.
+func (p *Parser) fetchToken() token.Token {
+	tok := p.l.NextToken()
+	for tok.Type == token.COMMENT {
+		if p.mode&ParseComments != 0 {
+			p.pendingComments = append(p.pendingComments, &ast.Comment{Token: tok, Text: tok.Literal})
+		}
+		tok = p.l.NextToken()
+	}
+	return tok
+}
+
+// leadComments drains the comments buffered since the last call and
+// returns them as a CommentGroup, or nil if none were buffered. Call it
+// right as a statement's Token is captured. The group holds the comments
+// that preceded that statement and, since the parser reads one token
+// ahead, any comments between the statement's first and second tokens.
+func (p *Parser) leadComments() *ast.CommentGroup {
+	if len(p.pendingComments) == 0 {
+		return nil
+	}
+	group := &ast.CommentGroup{List: p.pendingComments}
+	p.pendingComments = nil
+	return group
+}
+
+// trailingLineComment looks at the comments buffered while parsing the
+// statement that just finished on tok (its last consumed token, e.g. the
+// closing SEMI or RBRACE). The parser looks one token ahead, so a `// c`
+// immediately after tok has already been read into pendingComments by the
+// time this runs — along with, potentially, standalone comments further
+// down that actually lead the *next* statement, since fetchToken skips
+// every comment it meets in a row before returning the next real token.
+//
+// Only the first buffered comment can be tok's trailing comment, and only
+// if it shares tok's line; everything else was buffered ahead of time for
+// whatever comes next and must be left alone so the following
+// leadComments() call still sees it. When the first comment doesn't share
+// tok's line, nothing here belongs to this statement at all, and
+// pendingComments is left untouched.
+func (p *Parser) trailingLineComment(tok token.Token) *ast.CommentGroup {
+	if len(p.pendingComments) == 0 {
+		return nil
+	}
+
+	first := p.pendingComments[0]
+	if first.Token.Line != tok.Line {
+		return nil
+	}
+
+	p.pendingComments = p.pendingComments[1:]
+	return &ast.CommentGroup{List: []*ast.Comment{first}}
+}
+
+// attachLeadComment attaches comments to stmt's LeadComment slot (promoted
+// from the embedded comment struct every statement type carries), if both
+// are non-nil. It's a type switch rather than an interface method so a nil
+// concrete pointer boxed in the ast.Statement interface can't cause a
+// nil-receiver write.
+func attachLeadComment(stmt ast.Statement, comments *ast.CommentGroup) {
+	if comments == nil {
+		return
+	}
+	switch s := stmt.(type) {
+	case *ast.VarStatement:
+		if s != nil {
+			s.LeadComment = comments
+		}
+	case *ast.ReturnStatement:
+		if s != nil {
+			s.LeadComment = comments
+		}
+	case *ast.IfStatement:
+		if s != nil {
+			s.LeadComment = comments
+		}
+	case *ast.BlockStatement:
+		if s != nil {
+			s.LeadComment = comments
+		}
+	case *ast.ExpressionStatement:
+		if s != nil {
+			s.LeadComment = comments
+		}
+	}
+}
+
+// attachLineComment attaches comment to stmt's LineComment slot (promoted
+// from the same embedded comment struct as LeadComment), if both are
+// non-nil. See attachLeadComment for why this is a type switch rather than
+// an interface method.
+func attachLineComment(stmt ast.Statement, comment *ast.CommentGroup) {
+	if comment == nil {
+		return
+	}
+	switch s := stmt.(type) {
+	case *ast.VarStatement:
+		if s != nil {
+			s.LineComment = comment
+		}
+	case *ast.ReturnStatement:
+		if s != nil {
+			s.LineComment = comment
+		}
+	case *ast.IfStatement:
+		if s != nil {
+			s.LineComment = comment
+		}
+	case *ast.BlockStatement:
+		if s != nil {
+			s.LineComment = comment
+		}
+	case *ast.ExpressionStatement:
+		if s != nil {
+			s.LineComment = comment
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"testing"
+
+	"goto/ast"
+	"goto/lexer"
+)
+
+// parseProgramWithComments is like parseProgram but turns on ParseComments,
+// since comments are dropped by default.
+func parseProgramWithComments(t *testing.T, input string) (*ast.Program, *Parser) {
+	t.Helper()
+	p := NewWithMode(lexer.New(input), ParseComments)
+	program := p.ParseProgram()
+	if program == nil {
+		t.Fatalf("ParseProgram() returned nil for input %q", input)
+	}
+	return program, p
+}
+
+func commentText(t *testing.T, group *ast.CommentGroup) string {
+	t.Helper()
+	if group == nil || len(group.List) == 0 {
+		return ""
+	}
+	return group.List[0].Text
+}
+
+// TestComments_TrailingAndLeadingDontCollide is the exact regression from
+// the comment-scoping bug report: a trailing comment on one statement's
+// own line, followed by a standalone comment leading the next statement,
+// must attach to the statement each one actually belongs to.
+func TestComments_TrailingAndLeadingDontCollide(t *testing.T) {
+	input := "var x = 1; // trailing for x\n// leading for y\nvar y = 2;"
+
+	program, _ := parseProgramWithComments(t, input)
+	if len(program.Statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %#v", len(program.Statements), program.Statements)
+	}
+
+	x, ok := program.Statements[0].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("expected statement 0 to be *ast.VarStatement, got %T", program.Statements[0])
+	}
+	if got := commentText(t, x.LineComment); got != "// trailing for x" {
+		t.Errorf("x.LineComment = %q, want %q", got, "// trailing for x")
+	}
+	if x.LeadComment != nil {
+		t.Errorf("x.LeadComment = %v, want nil", x.LeadComment)
+	}
+
+	y, ok := program.Statements[1].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("expected statement 1 to be *ast.VarStatement, got %T", program.Statements[1])
+	}
+	if got := commentText(t, y.LeadComment); got != "// leading for y" {
+		t.Errorf("y.LeadComment = %q, want %q", got, "// leading for y")
+	}
+	if y.LineComment != nil {
+		t.Errorf("y.LineComment = %v, want nil", y.LineComment)
+	}
+}
+
+// TestComments_StandaloneLeadingCommentSurvives covers the simpler case
+// from the same bug report: with no trailing comment on the first
+// statement, a standalone comment between two statements must still reach
+// the second statement's LeadComment instead of being dropped.
+func TestComments_StandaloneLeadingCommentSurvives(t *testing.T) {
+	input := "var x = 1;\n// leading for y\nvar y = 2;"
+
+	program, _ := parseProgramWithComments(t, input)
+	if len(program.Statements) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %#v", len(program.Statements), program.Statements)
+	}
+
+	y, ok := program.Statements[1].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("expected statement 1 to be *ast.VarStatement, got %T", program.Statements[1])
+	}
+	if got := commentText(t, y.LeadComment); got != "// leading for y" {
+		t.Errorf("y.LeadComment = %q, want %q", got, "// leading for y")
+	}
+}
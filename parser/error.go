@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Severity classifies how serious a parser Error is. Most errors today are
+// Error severity; Warning is reserved for future use (e.g. deprecated
+// syntax) so callers can filter without a breaking API change later.
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// Position describes a location in the source being parsed. It is derived
+// from the token that triggered the error, not recomputed from scratch, so
+// it stays cheap to construct even when recovery produces many of them.
+//
+// There's no Offset field: token.Token doesn't carry one today, and a
+// field nobody sets is worse than not having it. Add it here once the
+// lexer threads byte offsets through tokens.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (pos Position) String() string {
+	if pos.Line <= 0 {
+		return "-"
+	}
+	if pos.Column <= 0 {
+		return fmt.Sprintf("%d", pos.Line)
+	}
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+}
+
+// ParseError is a single error produced while parsing, together with the
+// position it occurred at. It is modeled after go/scanner.Error.
+type ParseError struct {
+	Pos      Position
+	Msg      string
+	Severity Severity
+}
+
+func (e *ParseError) Error() string {
+	if e.Pos.Line == 0 {
+		return e.Msg
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// ErrorList is a list of *ParseErrors, in the order they were encountered.
+// It is modeled after go/scanner.ErrorList and lets callers consume parser
+// errors programmatically instead of scraping formatted strings.
+type ErrorList []*ParseError
+
+// Add appends a ParseError with the given position, message and severity.
+func (p *ErrorList) Add(pos Position, severity Severity, msg string) {
+	*p = append(*p, &ParseError{Pos: pos, Msg: msg, Severity: severity})
+}
+
+func (p ErrorList) Len() int      { return len(p) }
+func (p ErrorList) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p ErrorList) Less(i, j int) bool {
+	if p[i].Pos.Line != p[j].Pos.Line {
+		return p[i].Pos.Line < p[j].Pos.Line
+	}
+	return p[i].Pos.Column < p[j].Pos.Column
+}
+
+// Sort sorts an ErrorList by source position.
+func (p ErrorList) Sort() {
+	sort.Sort(p)
+}
+
+// Err returns an error equivalent to this error list, or nil if the list is
+// empty. This mirrors go/scanner.ErrorList.Err and is the idiomatic way to
+// fold an ErrorList back into a plain `error` return value.
+func (p ErrorList) Err() error {
+	if len(p) == 0 {
+		return nil
+	}
+	return p
+}
+
+// Error implements the error interface. It prints the first error and,
+// when there are more, how many others follow it.
+func (p ErrorList) Error() string {
+	switch len(p) {
+	case 0:
+		return "no errors"
+	case 1:
+		return p[0].Error()
+	}
+	msg := fmt.Sprintf("%s (and %d more errors)", p[0], len(p)-1)
+	return msg
+}
+
+// Strings returns the error messages as plain strings, preserving the shape
+// of the pre-ErrorList Errors() return value for callers that have not
+// migrated yet.
+func (p ErrorList) Strings() []string {
+	out := make([]string, len(p))
+	for i, e := range p {
+		out[i] = e.Error()
+	}
+	return out
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 
 	"goto/ast"
@@ -9,34 +10,84 @@ import (
 	"goto/token"
 )
 
-const ( // These represent the operator precedence values.
-	_int = iota
-	LOWEST
-	EQUALS      // ==
-	LESSGREATER // > or <
-	PLUS        // +
-	MULTIPLY    // *
-	PREFIX      // -X or !X
-	CALL        // myFunction(X)
+// Mode controls optional parser behaviors, enabled by OR-ing bit flags
+// together and passing the result to NewWithMode. The zero Mode matches
+// New's behavior.
+type Mode uint
+
+const (
+	// Trace causes every parse method wrapped with trace()/un() to print an
+	// indented BEGIN/END production trace, e.g. for debugging a Pratt
+	// precedence bug. See parser_tracing.go.
+	Trace Mode = 1 << iota
+	// SkipErrorRecovery disables the sync() resynchronization performed
+	// after a parse error, restoring the older fail-fast behavior where a
+	// bad token can leave later statements misparsed. Useful for tests that
+	// want to assert on the very first error only.
+	SkipErrorRecovery
+	// ParseComments attaches comment tokens to the AST instead of silently
+	// discarding them. Off by default, since most callers (the evaluator)
+	// have no use for comments and would rather not pay to carry them
+	// around. See comments.go.
+	ParseComments
 )
 
-var precedences = map[token.Type]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.LT_EQ:    LESSGREATER,
-	token.GT_EQ:    LESSGREATER,
-	token.PLUS:     PLUS,
-	token.MINUS:    PLUS,
-	token.DIVIDE:   MULTIPLY,
-	token.MULTIPLY: MULTIPLY,
-	token.LPAREN:   CALL,
+// Operator precedence values, exported so embedders can slot a new
+// operator between two existing tiers (e.g. RegisterInfix a `??` operator
+// at a precedence between PrecCompare and PrecSum) without resorting to
+// magic numbers. PrecOr and PrecAnd have no built-in operator yet but are
+// reserved so `&&`/`||`-style extensions have somewhere to live. The tiers
+// are spaced 10 apart, so there is room for new values between any two.
+const (
+	_ = iota * 10
+	PrecLowest
+	PrecOr
+	PrecAnd
+	PrecEquals
+	PrecCompare
+	PrecSum
+	PrecProduct
+	PrecPrefix
+	PrecCall
+	PrecIndex
+	PrecPostfix
+)
+
+// Deprecated: these are the pre-extension-API names for the Prec*
+// constants above, kept so existing callers keep compiling.
+const (
+	LOWEST      = PrecLowest
+	EQUALS      = PrecEquals  // ==
+	LESSGREATER = PrecCompare // > or <
+	PLUS        = PrecSum     // +
+	MULTIPLY    = PrecProduct // *
+	PREFIX      = PrecPrefix  // -X or !X
+	CALL        = PrecCall    // myFunction(X)
+	INDEX       = PrecIndex   // arr[i]
+	POSTFIX     = PrecPostfix // x++
+)
+
+func defaultPrecedences() map[token.Type]int {
+	return map[token.Type]int{
+		token.EQ:       EQUALS,
+		token.NOT_EQ:   EQUALS,
+		token.LT:       LESSGREATER,
+		token.GT:       LESSGREATER,
+		token.LT_EQ:    LESSGREATER,
+		token.GT_EQ:    LESSGREATER,
+		token.PLUS:     PLUS,
+		token.MINUS:    PLUS,
+		token.DIVIDE:   MULTIPLY,
+		token.MULTIPLY: MULTIPLY,
+		token.LPAREN:   CALL,
+		token.LBRACKET: INDEX,
+	}
 }
 
 type (
-	prefixParsefn func() ast.Expression
-	infixParsefn  func(ast.Expression) ast.Expression
+	prefixParsefn  func() ast.Expression
+	infixParsefn   func(ast.Expression) ast.Expression
+	postfixParsefn func(ast.Expression) ast.Expression
 )
 
 type Parser struct {
@@ -45,18 +96,38 @@ type Parser struct {
 	currToken token.Token
 	peekToken token.Token
 
-	errors []string
+	errors ErrorList
+
+	mode       Mode
+	traceOut   io.Writer
+	traceLevel int
+
+	pendingComments []*ast.Comment
+
+	precedences map[token.Type]int
 
-	prefixParsefns map[token.Type]prefixParsefn
-	infixParsefns  map[token.Type]infixParsefn
+	prefixParsefns  map[token.Type]prefixParsefn
+	infixParsefns   map[token.Type]infixParsefn
+	postfixParsefns map[token.Type]postfixParsefn
 }
 
+// New creates a Parser with the default Mode (no tracing, error recovery
+// enabled).
 func New(l *lexer.Lexer) *Parser {
+	return NewWithMode(l, 0)
+}
+
+// NewWithMode creates a Parser with the given Mode flags applied. See Mode
+// for the available bits.
+func NewWithMode(l *lexer.Lexer, mode Mode) *Parser {
 	p := &Parser{
-		l:      l,
-		errors: []string{},
+		l:    l,
+		mode: mode,
 	}
 
+	p.precedences = defaultPrecedences()
+	p.postfixParsefns = make(map[token.Type]postfixParsefn)
+
 	p.prefixParsefns = make(map[token.Type]prefixParsefn)
 	prefixfns := []struct {
 		token   token.Type
@@ -70,6 +141,9 @@ func New(l *lexer.Lexer) *Parser {
 		{token.FALSE, p.parseBoolean},
 		{token.STRING, p.parseString},
 		{token.LPAREN, p.parseGroupedExpression},
+		{token.FUNC, p.parseFunctionLiteral},
+		{token.LBRACKET, p.parseArrayLiteral},
+		{token.LBRACE, p.parseHashLiteral},
 	}
 
 	for _, fn := range prefixfns {
@@ -77,29 +151,104 @@ func New(l *lexer.Lexer) *Parser {
 	}
 
 	p.infixParsefns = make(map[token.Type]infixParsefn)
-	for keys := range precedences {
+	for keys := range p.precedences {
 		p.registerInfix(keys, p.parseInfixExpression)
 	}
 
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
+	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
 
 	p.setToken() // Only to be called for initialization of Parser pointers
 
 	return p
 }
 
-func (p *Parser) Errors() []string {
+func (p *Parser) Errors() ErrorList {
 	return p.errors
 }
 
+// Strings returns the accumulated errors as plain strings. It exists so
+// callers written against the pre-ErrorList API keep compiling; new code
+// should prefer Errors().
+func (p *Parser) Strings() []string {
+	return p.errors.Strings()
+}
+
+// currPos returns the position of the current token, for attaching to
+// errors raised while it is being consumed.
+func (p *Parser) currPos() Position {
+	return Position{Line: p.currToken.Line, Column: p.currToken.Column}
+}
+
+// peekPos returns the position of the peek token, for attaching to errors
+// raised about a token that hasn't been consumed yet (e.g. expectPeek
+// failures).
+func (p *Parser) peekPos() Position {
+	return Position{Line: p.peekToken.Line, Column: p.peekToken.Column}
+}
+
+// errorf records a formatted error at pos. It replaces the ad-hoc
+// `p.errors = append(...)` sites that used to build error strings by hand.
+func (p *Parser) errorf(pos Position, format string, args ...interface{}) {
+	p.errors.Add(pos, Error, fmt.Sprintf(format, args...))
+}
+
+// sync advances past tokens until it reaches a token in follow (or EOF), so
+// that a parse failure doesn't leave the parser's current/peek tokens
+// misaligned for the rest of the program. follow should contain the
+// synchronizing tokens for whatever statement bailed out: token.SEMI,
+// token.RBRACE, and statement-starting keywords are the usual members.
+func (p *Parser) sync(follow map[token.Type]bool) {
+	if p.mode&SkipErrorRecovery != 0 {
+		return
+	}
+	for !p.currTokenIs(token.EOF) {
+		if follow[p.currToken.Type] {
+			return
+		}
+		p.nextToken()
+	}
+}
+
+// stmtFollow is the default synchronizing set used by sync: it covers the
+// statement terminators and every statement-starting keyword, so recovery
+// lands back at a point parseStatement can resume from.
+var stmtFollow = map[token.Type]bool{
+	token.SEMI:   true,
+	token.RBRACE: true,
+	token.VAR:    true,
+	token.FUNC:   true,
+	token.IF:     true,
+	token.RETURN: true,
+}
+
+// recoveredAtBoundary reports whether currToken is a token sync() can
+// legitimately stop a failed statement on other than SEMI: a
+// statement-starting keyword, or RBRACE. Both mark a boundary that the
+// *enclosing* loop's own condition needs to see and act on — the start of
+// the next statement, or the end of the current block — rather than a
+// terminator this statement itself consumed. Only meaningful when the
+// statement being recovered from returned nil; a successful parse never
+// leaves currToken on a statement-start keyword, and the cases where it
+// legitimately lands on RBRACE (a statement ending in a block: a nested
+// block, an if or a func statement) go through the stmt != nil path, which
+// always advances unconditionally.
+func (p *Parser) recoveredAtBoundary() bool {
+	switch p.currToken.Type {
+	case token.RBRACE, token.VAR, token.FUNC, token.IF, token.RETURN:
+		return true
+	}
+	return false
+}
+
 func (p *Parser) nextToken() {
 	p.currToken = p.peekToken
-	p.peekToken = p.l.NextToken()
+	p.peekToken = p.fetchToken()
 }
 
 func (p *Parser) setToken() {
-	p.currToken = p.l.NextToken()
-	p.peekToken = p.l.NextToken()
+	p.currToken = p.fetchToken()
+	p.peekToken = p.fetchToken()
 }
 
 func (p *Parser) currTokenIs(t token.Type) bool {
@@ -111,9 +260,7 @@ func (p *Parser) peekTokenIs(t token.Type) bool {
 }
 
 func (p *Parser) peekError(t token.Type) {
-	msg := fmt.Sprintf("expected next token to be %s , got %s instead", t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
-
+	p.errorf(p.peekPos(), "expected next token to be %s , got %s instead", t, p.peekToken.Type)
 }
 
 func (p *Parser) expectPeek(t token.Type) bool {
@@ -135,6 +282,37 @@ func (p *Parser) registerInfix(Type token.Type, fn infixParsefn) {
 	p.infixParsefns[Type] = fn
 }
 
+// RegisterPrefix installs fn as the prefix parse function for t, letting
+// embedders add new leaf/unary syntax (e.g. a new literal kind) without
+// forking the parser package. It overwrites any existing registration for
+// t, including the built-ins.
+func (p *Parser) RegisterPrefix(t token.Type, fn func() ast.Expression) {
+	p.registerPrefix(t, fn)
+}
+
+// RegisterInfix installs fn as the infix parse function for t, for
+// embedders adding new binary operators (e.g. RSQL-style `LIKE`, `IN`).
+// Pair it with SetPrecedence so the new operator binds at the right tier.
+func (p *Parser) RegisterInfix(t token.Type, fn func(ast.Expression) ast.Expression) {
+	p.registerInfix(t, fn)
+}
+
+// RegisterPostfix installs fn as the postfix parse function for t (e.g.
+// `x++`). Postfix operators are checked on peekToken at the start of every
+// iteration of parseExpression's loop, ahead of the precedence check, so
+// they bind tighter than any infix operator.
+func (p *Parser) RegisterPostfix(t token.Type, fn func(ast.Expression) ast.Expression) {
+	p.postfixParsefns[t] = fn
+}
+
+// SetPrecedence sets the binding precedence used for t when it appears as
+// an infix operator; a token registered with RegisterPostfix is applied
+// before any precedence lookup. Use the Prec* constants, or any int
+// between them, to slot a new operator between two existing tiers.
+func (p *Parser) SetPrecedence(t token.Type, precedence int) {
+	p.precedences[t] = precedence
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	return &ast.Boolean{Token: p.currToken, Value: p.currTokenIs(token.TRUE)}
 }
@@ -149,8 +327,7 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	value, err := strconv.ParseInt(p.currToken.Literal, 0, 64)
 
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.currToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.errorf(p.currPos(), "could not parse %q as integer", p.currToken.Literal)
 		return nil
 	}
 
@@ -164,11 +341,11 @@ func (p *Parser) parseIdentifier() ast.Expression {
 }
 
 func (p *Parser) noPrefixParseFnError(t token.Type) {
-	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
+	p.errorf(p.currPos(), "no prefix parse function for %s found", t)
 }
 
 func (p *Parser) parsePrefixExpression() ast.Expression {
+	defer un(trace(p, "parsePrefixExpression"))
 	prefixexp := &ast.PrefixExpression{Token: p.currToken, Operator: p.currToken.Literal}
 
 	p.nextToken()
@@ -179,20 +356,21 @@ func (p *Parser) parsePrefixExpression() ast.Expression {
 }
 
 func (p *Parser) currPrecedence() int {
-	if p, ok := precedences[p.currToken.Type]; ok {
-		return p
+	if prec, ok := p.precedences[p.currToken.Type]; ok {
+		return prec
 	}
 	return LOWEST
 }
 
 func (p *Parser) peekPrecedence() int {
-	if p, ok := precedences[p.peekToken.Type]; ok {
-		return p
+	if prec, ok := p.precedences[p.peekToken.Type]; ok {
+		return prec
 	}
 	return LOWEST
 }
 
 func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
+	defer un(trace(p, "parseInfixExpression"))
 	infixexp := &ast.InfixExpression{
 		Token:    p.currToken,
 		Operator: p.currToken.Literal,
@@ -206,43 +384,111 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return infixexp
 }
 
-func (p *Parser) parseCallArguments() *ast.ExpressionList {
-	args := &ast.ExpressionList{Token: p.currToken}
+// parseExpressionList parses a comma-separated list of expressions up to
+// and including the given end token. It backs both call arguments and
+// array literal elements, which used to duplicate this loop.
+func (p *Parser) parseExpressionList(end token.Type) *ast.ExpressionList {
+	list := &ast.ExpressionList{Token: p.currToken}
 
 	p.nextToken()
 
-	for !p.currTokenIs(token.RPAREN) && !p.currTokenIs(token.EOF) {
+	for !p.currTokenIs(end) && !p.currTokenIs(token.EOF) {
 		exp := p.parseExpression(LOWEST)
-		args.Expressions = append(args.Expressions, &exp)
+		list.Expressions = append(list.Expressions, &exp)
 
 		if p.peekTokenIs(token.COMMA) {
 			p.nextToken() // TODO: add a utility to do multiple token jumps
 			p.nextToken()
 			continue
 		}
-		if p.peekTokenIs(token.RPAREN) {
+		if p.peekTokenIs(end) {
 			p.nextToken()
 			break
 		}
-		// TODO: error message
+		p.errorf(p.peekPos(), "expected , or %s in expression list, got %s instead", end, p.peekToken.Type)
+		p.sync(map[token.Type]bool{end: true, token.SEMI: true})
 		return nil
 	}
 
-	return args
+	return list
 }
 
 func (p *Parser) parseCallExpression(left ast.Expression) ast.Expression {
-	exp := &ast.CallExpression{Token: p.currToken}
-	fname, ok := left.(*ast.Identifier)
-	if !ok {
+	defer un(trace(p, "parseCallExpression"))
+	// left may be any expression now, not just an *ast.Identifier: a
+	// FunctionLiteral (IIFE), an index expression, or the result of another
+	// call are all valid callees. The evaluator resolves whatever it is.
+	exp := &ast.CallExpression{Token: p.currToken, Function: left}
+	exp.ArgumentList = p.parseExpressionList(token.RPAREN)
+	return exp
+}
+
+// parseArrayLiteral parses `[a, b, c]`. It is registered as the prefix
+// parse function for token.LBRACKET.
+func (p *Parser) parseArrayLiteral() ast.Expression {
+	defer un(trace(p, "parseArrayLiteral"))
+	arr := &ast.ArrayLiteral{Token: p.currToken}
+	arr.Elements = p.parseExpressionList(token.RBRACKET)
+	return arr
+}
+
+// parseIndexExpression parses `arr[i]`. It is registered as the infix
+// parse function for token.LBRACKET at INDEX precedence, which binds
+// tighter than CALL so `arr[i]()` and `f()[i]` both parse as expected.
+func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
+	defer un(trace(p, "parseIndexExpression"))
+	exp := &ast.IndexExpression{Token: p.currToken, Left: left}
+
+	p.nextToken()
+	exp.Index = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RBRACKET) {
+		p.sync(stmtFollow)
 		return nil
 	}
-	exp.FunctionName = fname
-	exp.ArgumentList = p.parseCallArguments()
+
 	return exp
 }
 
+// parseHashLiteral parses `{k: v, k2: v2}`. It is registered as the prefix
+// parse function for token.LBRACE, but that only fires when parseExpression
+// reaches a `{` — parseStatement handles a leading `{` as a block
+// statement before expression parsing ever gets involved, so block
+// statements and hash literals don't collide.
+func (p *Parser) parseHashLiteral() ast.Expression {
+	defer un(trace(p, "parseHashLiteral"))
+	hash := &ast.HashLiteral{Token: p.currToken}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		key := p.parseExpression(LOWEST)
+
+		if !p.expectPeek(token.COLON) {
+			p.sync(map[token.Type]bool{token.RBRACE: true, token.SEMI: true})
+			return nil
+		}
+
+		p.nextToken()
+		value := p.parseExpression(LOWEST)
+
+		hash.Pairs = append(hash.Pairs, ast.HashPair{Key: key, Value: value})
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			p.sync(map[token.Type]bool{token.RBRACE: true, token.SEMI: true})
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		p.sync(stmtFollow)
+		return nil
+	}
+
+	return hash
+}
+
 func (p *Parser) parseExpression(precedence int) ast.Expression { // returns expression on the same or higher precedence level
+	defer un(trace(p, "parseExpression"))
 	prefix := p.prefixParsefns[p.currToken.Type]
 
 	if prefix == nil {
@@ -252,10 +498,26 @@ func (p *Parser) parseExpression(precedence int) ast.Expression { // returns exp
 
 	leftExp := prefix()
 
-	for !p.peekTokenIs(token.SEMI) && precedence < p.peekPrecedence() {
+	for !p.peekTokenIs(token.SEMI) {
+		// Postfix operators (e.g. x++) bind tighter than anything else, so
+		// they're checked ahead of precedence rather than folded into
+		// precedences/infixParsefns. Checking them on every iteration,
+		// not just once after the infix loop runs dry, lets something
+		// like `x++ + y` keep parsing the `+ y` that follows the postfix
+		// expression instead of returning early.
+		if postfix, ok := p.postfixParsefns[p.peekToken.Type]; ok {
+			p.nextToken()
+			leftExp = postfix(leftExp)
+			continue
+		}
+
+		if precedence >= p.peekPrecedence() {
+			break
+		}
+
 		infix := p.infixParsefns[p.peekToken.Type]
 		if infix == nil {
-			return leftExp
+			break
 		}
 
 		p.nextToken()
@@ -279,15 +541,18 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 }
 
 func (p *Parser) parseVarStatement() *ast.VarStatement {
+	defer un(trace(p, "parseVarStatement"))
 	stmt := &ast.VarStatement{Token: p.currToken}
 
 	if !p.expectPeek(token.IDENT) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
 	stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
 
 	if !p.expectPeek(token.ASSIGN) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
@@ -296,6 +561,7 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 	stmt.Value = p.parseExpression(LOWEST)
 
 	if !p.expectPeek(token.SEMI) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
@@ -303,6 +569,7 @@ func (p *Parser) parseVarStatement() *ast.VarStatement {
 }
 
 func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
+	defer un(trace(p, "parseReturnStatement"))
 	stmt := &ast.ReturnStatement{Token: p.currToken}
 
 	p.nextToken()
@@ -310,6 +577,7 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	stmt.ReturnValue = p.parseExpression(LOWEST)
 
 	if !p.expectPeek(token.SEMI) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
@@ -317,6 +585,7 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 }
 
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
+	defer un(trace(p, "parseBlockStatement"))
 	block := &ast.BlockStatement{Token: p.currToken}
 	p.nextToken()
 	for !p.currTokenIs(token.RBRACE) && !p.currTokenIs(token.EOF) {
@@ -324,8 +593,14 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 		stmt := p.parseStatement()
 		if stmt != nil {
 			block.Statements = append(block.Statements, stmt)
+			p.nextToken()
+		} else if !p.recoveredAtBoundary() {
+			// A failed statement that recovered via sync() landed on a
+			// plain terminator (SEMI), not a boundary the loop condition
+			// above already handles; consume it like a normal statement
+			// end would.
+			p.nextToken()
 		}
-		p.nextToken()
 
 	}
 
@@ -333,6 +608,7 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 }
 
 func (p *Parser) parseIfStatement() *ast.IfStatement {
+	defer un(trace(p, "parseIfStatement"))
 	stmt := &ast.IfStatement{Token: p.currToken}
 
 	p.nextToken()
@@ -340,6 +616,7 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 	stmt.Condition = p.parseExpression(LOWEST)
 
 	if !p.expectPeek(token.LBRACE) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
@@ -355,6 +632,7 @@ func (p *Parser) parseIfStatement() *ast.IfStatement {
 		p.nextToken()
 		stmt.FollowIf = p.parseIfStatement()
 	} else if !p.expectPeek(token.LBRACE) {
+		p.sync(stmtFollow)
 		return nil
 	} else {
 		stmt.Alternative = p.parseBlockStatement()
@@ -373,7 +651,8 @@ func (p *Parser) parseIdentifierList() *ast.IdentifierList {
 		ident, ok := p.parseIdentifier().(*ast.Identifier)
 
 		if !ok {
-			// TODO: Error message
+			p.errorf(p.currPos(), "expected identifier in parameter list, got %s instead", p.currToken.Type)
+			p.sync(map[token.Type]bool{token.RPAREN: true, token.SEMI: true})
 			return nil
 		}
 
@@ -389,40 +668,74 @@ func (p *Parser) parseIdentifierList() *ast.IdentifierList {
 			p.nextToken()
 			break
 		}
-		// TODO: error message
+		p.errorf(p.peekPos(), "expected , or ) in parameter list, got %s instead", p.peekToken.Type)
+		p.sync(map[token.Type]bool{token.RPAREN: true, token.SEMI: true})
 		return nil
 	}
 
 	return identlist
 }
 
-func (p *Parser) parseFuncStatement() *ast.FuncStatement {
-	stmt := &ast.FuncStatement{Token: p.currToken}
+// parseFuncStatement parses `func name(params) { body }` as sugar for
+// `var name = func(params) { body };`, so the resulting AST binds an
+// *ast.FunctionLiteral the same way a closure assigned to a var would. The
+// evaluator then only needs one calling convention, whether the function
+// came from a statement or an expression.
+func (p *Parser) parseFuncStatement() *ast.VarStatement {
+	defer un(trace(p, "parseFuncStatement"))
+	stmt := &ast.VarStatement{Token: p.currToken}
+	lit := &ast.FunctionLiteral{Token: p.currToken}
 
-	p.nextToken()
+	if !p.expectPeek(token.IDENT) {
+		p.sync(stmtFollow)
+		return nil
+	}
 
-	name, ok := p.parseIdentifier().(*ast.Identifier)
+	stmt.Name = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
 
-	if !ok {
-		//TODO: Error message
+	if !p.expectPeek(token.LPAREN) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
-	stmt.Name = name
+	lit.Parameters = p.parseIdentifierList()
+
+	if !p.expectPeek(token.LBRACE) {
+		p.sync(stmtFollow)
+		return nil
+	}
+
+	lit.Body = p.parseBlockStatement()
+
+	stmt.Value = lit
+
+	return stmt
+}
+
+// parseFunctionLiteral parses a function literal in expression position,
+// e.g. `func(x, y) { return x + y; }`. It is registered as the prefix parse
+// function for token.FUNC so function literals can appear anywhere an
+// expression can: bound to a var, passed as a call argument, or invoked
+// immediately as `func(x){ return x*2; }(5)`.
+func (p *Parser) parseFunctionLiteral() ast.Expression {
+	defer un(trace(p, "parseFunctionLiteral"))
+	lit := &ast.FunctionLiteral{Token: p.currToken}
 
 	if !p.expectPeek(token.LPAREN) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
-	stmt.ParameterList = p.parseIdentifierList()
+	lit.Parameters = p.parseIdentifierList()
 
 	if !p.expectPeek(token.LBRACE) {
+		p.sync(stmtFollow)
 		return nil
 	}
 
-	stmt.FuncBody = p.parseBlockStatement()
+	lit.Body = p.parseBlockStatement()
 
-	return stmt
+	return lit
 }
 
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
@@ -438,20 +751,58 @@ func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
 }
 
 func (p *Parser) parseStatement() ast.Statement {
+	comments := p.leadComments()
+	start := p.currToken
+
+	// Each case assigns through a concretely-typed local before stmt,
+	// rather than straight into the ast.Statement interface: parseVarStatement
+	// et al. return a nil *ast.XStatement on error, and boxing a nil pointer
+	// straight into an interface produces a non-nil interface value. Without
+	// this, a recovered error would come back as a "non-nil" stmt that's
+	// actually nil underneath, defeating every nil check in the callers
+	// below (ParseProgram, parseBlockStatement).
+	var stmt ast.Statement
 	switch p.currToken.Type {
 	case token.VAR:
-		return p.parseVarStatement()
+		if s := p.parseVarStatement(); s != nil {
+			stmt = s
+		}
 	case token.RETURN:
-		return p.parseReturnStatement()
+		if s := p.parseReturnStatement(); s != nil {
+			stmt = s
+		}
 	case token.IF:
-		return p.parseIfStatement()
+		if s := p.parseIfStatement(); s != nil {
+			stmt = s
+		}
 	case token.LBRACE:
-		return p.parseBlockStatement()
+		stmt = p.parseBlockStatement()
 	case token.FUNC:
-		return p.parseFuncStatement()
+		// `func(` starts a function literal (e.g. an immediately invoked
+		// one), not a named function statement.
+		if p.peekTokenIs(token.LPAREN) {
+			stmt = p.parseExpressionStatement()
+		} else if s := p.parseFuncStatement(); s != nil {
+			stmt = s
+		}
 	default:
-		return p.parseExpressionStatement()
+		stmt = p.parseExpressionStatement()
 	}
+
+	// A statement that failed on the token right after its keyword is still
+	// on that keyword, which sync() stops at; step past it so the callers'
+	// loops don't parse the same statement again.
+	if stmt == nil && p.currToken == start {
+		p.nextToken()
+		p.sync(stmtFollow)
+	}
+
+	lineComment := p.trailingLineComment(p.currToken)
+
+	attachLeadComment(stmt, comments)
+	attachLineComment(stmt, lineComment)
+
+	return stmt
 }
 
 func (p *Parser) ParseProgram() *ast.Program {
@@ -463,9 +814,14 @@ func (p *Parser) ParseProgram() *ast.Program {
 
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
+			p.nextToken()
+		} else if !p.recoveredAtBoundary() {
+			// A failed statement that recovered via sync() landed on a
+			// plain terminator (SEMI), not a boundary the next loop
+			// iteration already handles; consume it like a normal
+			// statement end would.
+			p.nextToken()
 		}
-
-		p.nextToken()
 	}
 
 	return program
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const traceIdentPlaceholder = "\t"
+
+// traceGuard carries what un() needs to print the matching END line. trace
+// returns one of these so callers can write the familiar Monkey idiom:
+//
+//	defer un(trace(p, "parseExpression"))
+type traceGuard struct {
+	p   *Parser
+	msg string
+}
+
+// traceWriter is where trace output goes when Mode&Trace is set. It
+// defaults to os.Stdout but can be redirected with Parser.SetTraceOutput,
+// which is handy for golden-file tests.
+func (p *Parser) traceWriter() io.Writer {
+	if p.traceOut != nil {
+		return p.traceOut
+	}
+	return os.Stdout
+}
+
+// SetTraceOutput redirects trace() / un() output away from os.Stdout.
+func (p *Parser) SetTraceOutput(w io.Writer) {
+	p.traceOut = w
+}
+
+func identLevel(level int) string {
+	return strings.Repeat(traceIdentPlaceholder, level-1)
+}
+
+func tracePrint(p *Parser, fs string) {
+	fmt.Fprintf(p.traceWriter(), "%s%s\n", identLevel(p.traceLevel), fs)
+}
+
+// trace prints a "BEGIN msg" line annotated with the current token and
+// precedence level, then returns a guard for the matching un() call:
+//
+//	defer un(trace(p, "parseExpression"))
+//
+// This is the same two-function dance Monkey's parser_tracing.go uses, and
+// it only costs anything when Mode&Trace is set.
+func trace(p *Parser, msg string) *traceGuard {
+	if p.mode&Trace == 0 {
+		return &traceGuard{p: p, msg: msg}
+	}
+	p.traceLevel++
+	tracePrint(p, fmt.Sprintf("BEGIN %s (curr=%s precedence=%d)", msg, p.currToken.Type, p.currPrecedence()))
+	return &traceGuard{p: p, msg: msg}
+}
+
+// un prints the matching "END msg" line. See trace for usage.
+func un(tg *traceGuard) {
+	if tg.p.mode&Trace == 0 {
+		return
+	}
+	tracePrint(tg.p, fmt.Sprintf("END %s", tg.msg))
+	tg.p.traceLevel--
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"testing"
+
+	"goto/ast"
+	"goto/lexer"
+)
+
+// parseProgram is a small helper that runs the full pipeline used by every
+// test below: lex input, parse it, and hand back both the program and the
+// parser (so callers can inspect Errors()).
+func parseProgram(t *testing.T, input string) (*ast.Program, *Parser) {
+	t.Helper()
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+	if program == nil {
+		t.Fatalf("ParseProgram() returned nil for input %q", input)
+	}
+	return program, p
+}
+
+// TestRecovery_MissingSemicolon covers the exact regression from the
+// sync-recovery request: a statement missing its semicolon should not
+// desynchronize the parser for everything that follows it.
+func TestRecovery_MissingSemicolon(t *testing.T) {
+	input := `var x = 5 var y = 10;`
+
+	program, p := parseProgram(t, input)
+
+	if len(p.Errors()) == 0 {
+		t.Fatalf("expected at least one error for missing semicolon, got none")
+	}
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("expected exactly 1 recovered statement, got %d: %#v", len(program.Statements), program.Statements)
+	}
+
+	stmt, ok := program.Statements[0].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("expected *ast.VarStatement, got %T", program.Statements[0])
+	}
+	if stmt.Name.Value != "y" {
+		t.Fatalf("expected recovered statement to bind %q, got %q", "y", stmt.Name.Value)
+	}
+}
+
+// TestRecovery_BlockClosingBraceNotConsumed covers a block whose last
+// statement fails to parse and recovers by landing exactly on the block's
+// own closing brace: that brace must end the block, not get skipped so
+// the next top-level statement is absorbed into it.
+func TestRecovery_BlockClosingBraceNotConsumed(t *testing.T) {
+	input := "func f() { return }\nvar y = 1;"
+
+	program, _ := parseProgram(t, input)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("expected 2 top-level statements, got %d: %#v", len(program.Statements), program.Statements)
+	}
+
+	fn, ok := program.Statements[0].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("expected statement 0 to be *ast.VarStatement, got %T", program.Statements[0])
+	}
+	if fn.Name.Value != "f" {
+		t.Fatalf("expected first statement to bind %q, got %q", "f", fn.Name.Value)
+	}
+	lit, ok := fn.Value.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("expected f's value to be *ast.FunctionLiteral, got %T", fn.Value)
+	}
+	if len(lit.Body.Statements) != 0 {
+		t.Fatalf("expected f's body to have no statements (the bare return failed to parse), got %d: %#v",
+			len(lit.Body.Statements), lit.Body.Statements)
+	}
+
+	y, ok := program.Statements[1].(*ast.VarStatement)
+	if !ok {
+		t.Fatalf("expected statement 1 to be *ast.VarStatement, got %T", program.Statements[1])
+	}
+	if y.Name.Value != "y" {
+		t.Fatalf("expected second statement to bind %q, got %q", "y", y.Name.Value)
+	}
+}
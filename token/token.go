@@ -0,0 +1,80 @@
+// Package token defines the lexical token types produced by the lexer and
+// consumed by the parser.
+package token
+
+// Type identifies the kind of a Token. It's a string rather than an int so
+// token types print readably in parser error messages without a
+// String() method.
+type Type string
+
+// Token is a single lexical token together with the source position it
+// started at, for attaching to parser errors.
+type Token struct {
+	Type    Type
+	Literal string
+	Line    int
+	Column  int
+}
+
+// Token types.
+const (
+	ILLEGAL Type = "ILLEGAL"
+	EOF     Type = "EOF"
+	COMMENT Type = "COMMENT"
+
+	IDENT  Type = "IDENT"
+	INT    Type = "INT"
+	STRING Type = "STRING"
+
+	ASSIGN   Type = "="
+	PLUS     Type = "+"
+	MINUS    Type = "-"
+	NOT      Type = "!"
+	DIVIDE   Type = "/"
+	MULTIPLY Type = "*"
+
+	LT     Type = "<"
+	GT     Type = ">"
+	LT_EQ  Type = "<="
+	GT_EQ  Type = ">="
+	EQ     Type = "=="
+	NOT_EQ Type = "!="
+
+	COMMA Type = ","
+	SEMI  Type = ";"
+	COLON Type = ":"
+
+	LPAREN   Type = "("
+	RPAREN   Type = ")"
+	LBRACE   Type = "{"
+	RBRACE   Type = "}"
+	LBRACKET Type = "["
+	RBRACKET Type = "]"
+
+	FUNC   Type = "FUNC"
+	VAR    Type = "VAR"
+	TRUE   Type = "TRUE"
+	FALSE  Type = "FALSE"
+	IF     Type = "IF"
+	ELSE   Type = "ELSE"
+	RETURN Type = "RETURN"
+)
+
+// keywords maps identifier literals to their keyword token type.
+var keywords = map[string]Type{
+	"func":   FUNC,
+	"var":    VAR,
+	"true":   TRUE,
+	"false":  FALSE,
+	"if":     IF,
+	"else":   ELSE,
+	"return": RETURN,
+}
+
+// LookupIdent returns the keyword Type for ident, or IDENT if it isn't one.
+func LookupIdent(ident string) Type {
+	if tok, ok := keywords[ident]; ok {
+		return tok
+	}
+	return IDENT
+}